@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli/v2"
+
+	"github.com/cyber-boost/pak/pkg/build"
+	"github.com/cyber-boost/pak/pkg/paths"
+)
+
+func cacheClean(c *cli.Context) error {
+	olderThan, err := parseDuration(c.String("older-than"))
+	if err != nil {
+		return fmt.Errorf("invalid --older-than: %v", err)
+	}
+
+	cacheDir, err := paths.PackageCacheDir()
+	if err != nil {
+		return err
+	}
+
+	removed, err := build.NewCache(cacheDir).Prune(olderThan)
+	if err != nil {
+		return err
+	}
+	color.Green("✔ pruned %d cached artifact(s)", removed)
+	return nil
+}
+
+// parseDuration extends time.ParseDuration with a "d" (day) unit, since
+// cache retention is usually expressed in days rather than hours.
+func parseDuration(s string) (time.Duration, error) {
+	if strings.HasSuffix(s, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(s, "d"))
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(s)
+}
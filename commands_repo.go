@@ -0,0 +1,282 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli/v2"
+
+	"github.com/cyber-boost/pak/pkg/build"
+	"github.com/cyber-boost/pak/pkg/paths"
+	"github.com/cyber-boost/pak/pkg/repos"
+)
+
+// buildAndInstall builds pkg's spec (or reuses a fresh cached artifact)
+// and installs the resulting artifact through the detected distro's
+// native package manager. rebuild bypasses the cache.
+func buildAndInstall(pkg repos.Package, rebuild bool) error {
+	specPath, err := repos.SpecPath(pkg)
+	if err != nil {
+		return err
+	}
+	spec, err := build.LoadSpec(specPath)
+	if err != nil {
+		return err
+	}
+
+	cacheDir, err := paths.PackageCacheDir()
+	if err != nil {
+		return err
+	}
+	if err := paths.EnsureDir(cacheDir); err != nil {
+		return err
+	}
+
+	mgr, ok := nativeManager()
+	if !ok {
+		return fmt.Errorf("no native package manager detected for this distro")
+	}
+	format := formatFor(mgr.Name())
+
+	cache := build.NewCache(cacheDir)
+	hash, err := build.SpecHash(spec, map[string]string{"format": string(format)})
+	if err != nil {
+		return err
+	}
+
+	var artifactPath string
+	if !rebuild {
+		if cached, fresh, err := cache.Lookup(hash, spec.Version); err == nil && fresh {
+			color.Blue("Using cached artifact %s (built %s)", cached.Path, cached.BuiltAt.Format("2006-01-02"))
+			artifactPath = cached.Path
+		}
+	}
+
+	if artifactPath == "" {
+		artifacts, err := build.NewBuilder(cacheDir).Build(context.Background(), spec, build.Options{
+			Formats: []build.Format{format},
+			OutDir:  cacheDir,
+			Clean:   rebuild,
+		})
+		if err != nil {
+			return err
+		}
+		artifactPath = artifacts[0]
+
+		if err := cache.Record(hash, build.Artifact{
+			Path:    artifactPath,
+			Hash:    hash,
+			Format:  format,
+			Version: spec.Version,
+			BuiltAt: time.Now(),
+		}); err != nil {
+			return fmt.Errorf("record artifact in cache: %w", err)
+		}
+	}
+
+	return mgr.Install(artifactPath)
+}
+
+func formatFor(managerName string) build.Format {
+	switch managerName {
+	case "apt-get":
+		return build.FormatDeb
+	case "dnf", "zypper":
+		return build.FormatRPM
+	case "pacman":
+		return build.FormatPacman
+	case "apk":
+		return build.FormatAPK
+	default:
+		return build.FormatDeb
+	}
+}
+
+// openRepoManager loads the persistent config and opens the repo index,
+// the pair every repo/search/info/install-by-name command needs.
+func openRepoManager() (*repos.Manager, error) {
+	cfg, err := paths.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return repos.NewManager(cfg)
+}
+
+func repoList(c *cli.Context) error {
+	mgr, err := openRepoManager()
+	if err != nil {
+		return err
+	}
+	defer mgr.Close()
+
+	repoList := mgr.List()
+	if len(repoList) == 0 {
+		fmt.Println("No repos configured. Add one with: pak-sh repo add <name> <url>")
+		return nil
+	}
+	for _, r := range repoList {
+		fmt.Printf("%s\t%s\n", r.Name, r.URL)
+	}
+	return nil
+}
+
+func repoAdd(c *cli.Context) error {
+	if c.NArg() != 2 {
+		return fmt.Errorf("usage: pak-sh repo add <name> <url>")
+	}
+	mgr, err := openRepoManager()
+	if err != nil {
+		return err
+	}
+	defer mgr.Close()
+
+	name, url := c.Args().Get(0), c.Args().Get(1)
+	color.Blue("Adding repo %s (%s)...", name, url)
+	if err := mgr.Add(name, url); err != nil {
+		return err
+	}
+	color.Green("✔ added and indexed %s", name)
+	return nil
+}
+
+func repoRemove(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return fmt.Errorf("usage: pak-sh repo remove <name>")
+	}
+	mgr, err := openRepoManager()
+	if err != nil {
+		return err
+	}
+	defer mgr.Close()
+
+	return mgr.Remove(c.Args().First())
+}
+
+func repoRefresh(c *cli.Context) error {
+	mgr, err := openRepoManager()
+	if err != nil {
+		return err
+	}
+	defer mgr.Close()
+
+	if c.NArg() == 0 {
+		color.Blue("Refreshing all repos...")
+		return mgr.RefreshAll()
+	}
+	name := c.Args().First()
+	color.Blue("Refreshing %s...", name)
+	return mgr.Refresh(name)
+}
+
+func searchPak(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return fmt.Errorf("usage: pak-sh search <query>")
+	}
+	mgr, err := openRepoManager()
+	if err != nil {
+		return err
+	}
+	defer mgr.Close()
+
+	matches, err := mgr.Index().Search(c.Args().First())
+	if err != nil {
+		return err
+	}
+	if len(matches) == 0 {
+		fmt.Println("No matching packages found.")
+		return nil
+	}
+	for _, p := range matches {
+		fmt.Printf("%s/%s %s - %s\n", p.Repo, p.Name, p.Version, p.Description)
+	}
+	return nil
+}
+
+func infoPak(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return fmt.Errorf("usage: pak-sh info <name>")
+	}
+	mgr, err := openRepoManager()
+	if err != nil {
+		return err
+	}
+	defer mgr.Close()
+
+	matches, err := mgr.Index().ByName(c.Args().First())
+	if err != nil {
+		return err
+	}
+	if len(matches) == 0 {
+		return fmt.Errorf("package %q not found in any configured repo", c.Args().First())
+	}
+	for _, p := range matches {
+		fmt.Printf("Name:        %s\n", p.Name)
+		fmt.Printf("Version:     %s\n", p.Version)
+		fmt.Printf("Description: %s\n", p.Description)
+		fmt.Printf("Repo:        %s\n", p.Repo)
+		fmt.Printf("Depends:     %s\n", p.Depends)
+		fmt.Printf("Provides:    %s\n", p.Provides)
+		fmt.Printf("Replaces:    %s\n", p.Replaces)
+		fmt.Println()
+	}
+	return nil
+}
+
+// installNamed resolves name through the repo index and hands the
+// matching spec to the builder, prompting the user when more than one
+// repo provides the same package name.
+func installNamed(c *cli.Context, name string) error {
+	mgr, err := openRepoManager()
+	if err != nil {
+		return err
+	}
+	defer mgr.Close()
+
+	pkg, err := mgr.Index().Resolve(name)
+	if err != nil {
+		var ambiguous *repos.ErrAmbiguous
+		if !asAmbiguous(err, &ambiguous) {
+			return err
+		}
+		pkg, err = promptAmbiguous(ambiguous)
+		if err != nil {
+			return err
+		}
+	}
+
+	color.Blue("🚀 Installing %s %s from %s...", pkg.Name, pkg.Version, pkg.Repo)
+	return buildAndInstall(pkg, c.Bool("clean"))
+}
+
+func asAmbiguous(err error, target **repos.ErrAmbiguous) bool {
+	amb, ok := err.(*repos.ErrAmbiguous)
+	if ok {
+		*target = amb
+	}
+	return ok
+}
+
+func promptAmbiguous(amb *repos.ErrAmbiguous) (repos.Package, error) {
+	fmt.Printf("%q is provided by multiple repos:\n", amb.Name)
+	for i, m := range amb.Matches {
+		fmt.Printf("  [%d] %s (%s %s)\n", i+1, m.Repo, m.Name, m.Version)
+	}
+	fmt.Print("Choose one: ")
+
+	reader := bufio.NewReader(os.Stdin)
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return repos.Package{}, err
+	}
+	idx, err := strconv.Atoi(strings.TrimSpace(line))
+	if err != nil || idx < 1 || idx > len(amb.Matches) {
+		return repos.Package{}, fmt.Errorf("invalid selection %q", line)
+	}
+	return amb.Matches[idx-1], nil
+}
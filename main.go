@@ -3,16 +3,20 @@ package main
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/urfave/cli/v2"
+
+	"github.com/cyber-boost/pak/pkg/build"
+	"github.com/cyber-boost/pak/pkg/distro"
+	"github.com/cyber-boost/pak/pkg/paths"
 )
 
 const (
-	version = "2.0.0"
+	version     = "2.0.0"
 	description = "PAK.sh - Universal Package Automation Kit Wrapper"
 )
 
@@ -24,10 +28,18 @@ func main() {
 		Usage:       "Professional wrapper for PAK.sh installation and management",
 		Commands: []*cli.Command{
 			{
-				Name:    "install",
-				Aliases: []string{"i"},
-				Usage:   "Install PAK.sh locally",
-				Action:  installPak,
+				Name:      "install",
+				Aliases:   []string{"i"},
+				Usage:     "Install PAK.sh locally, or a named package from a configured repo",
+				ArgsUsage: "[name]",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:    "clean",
+						Aliases: []string{"rebuild"},
+						Usage:   "bypass the artifact cache and rebuild from source",
+					},
+				},
+				Action: installPak,
 			},
 			{
 				Name:    "run",
@@ -53,6 +65,66 @@ func main() {
 				Usage:   "Show version information",
 				Action:  showVersion,
 			},
+			{
+				Name:      "build",
+				Usage:     "Build a package from a spec file",
+				ArgsUsage: "<spec>",
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{
+						Name:  "format",
+						Usage: "package format(s) to emit (deb, rpm, apk, pacman)",
+					},
+					&cli.StringFlag{
+						Name:  "output",
+						Usage: "directory to write built artifacts to",
+					},
+					&cli.BoolFlag{
+						Name:  "clean",
+						Usage: "clean the build sandbox before building",
+					},
+				},
+				Action: buildPak,
+			},
+			{
+				Name:  "repo",
+				Usage: "Manage remote package spec repositories",
+				Subcommands: []*cli.Command{
+					{Name: "list", Usage: "List configured repos", Action: repoList},
+					{Name: "add", Usage: "Add a repo", ArgsUsage: "<name> <url>", Action: repoAdd},
+					{Name: "remove", Usage: "Remove a repo", ArgsUsage: "<name>", Action: repoRemove},
+					{Name: "refresh", Usage: "Refresh one or all repos", ArgsUsage: "[name]", Action: repoRefresh},
+				},
+			},
+			{
+				Name:      "search",
+				Usage:     "Search indexed package specs",
+				ArgsUsage: "<query>",
+				Action:    searchPak,
+			},
+			{
+				Name:      "info",
+				Usage:     "Show details for an indexed package",
+				ArgsUsage: "<name>",
+				Action:    infoPak,
+			},
+			{
+				Name:  "cache",
+				Usage: "Manage the built-artifact cache",
+				Subcommands: []*cli.Command{
+					{
+						Name:  "clean",
+						Usage: "Prune cached artifacts older than a duration",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  "older-than",
+								Value: "30d",
+								Usage: "prune artifacts built before this long ago (e.g. 30d, 12h)",
+							},
+						},
+						Action: cacheClean,
+					},
+				},
+			},
 		},
 		UsageText: "pak-sh <command> [options]",
 		Authors: []*cli.Author{
@@ -71,6 +143,10 @@ func main() {
 			fmt.Println("  pak-sh run <cmd>   Run PAK.sh command")
 			fmt.Println("  pak-sh status      Check installation status")
 			fmt.Println("  pak-sh update      Update PAK.sh installation")
+			fmt.Println("  pak-sh build       Build a package from a spec file")
+			fmt.Println("  pak-sh repo        Manage remote package spec repositories")
+			fmt.Println("  pak-sh search      Search indexed package specs")
+			fmt.Println("  pak-sh info        Show details for an indexed package")
 			fmt.Println("  pak-sh version     Show version information")
 			fmt.Println()
 			fmt.Println("Examples:")
@@ -91,20 +167,66 @@ func main() {
 }
 
 func installPak(c *cli.Context) error {
+	if err := ensureXDGDirs(); err != nil {
+		return fmt.Errorf("prepare pak-sh directories: %v", err)
+	}
+
+	if c.NArg() > 0 {
+		return installNamed(c, c.Args().First())
+	}
+
 	color.Blue("🚀 Installing PAK.sh...")
-	
-	// Find wrapper script
+
+	if mgr, ok := nativeManager(); ok {
+		color.Blue("Using %s (detected distro package manager)", mgr.Name())
+		if err := mgr.Install("pak-sh"); err != nil {
+			return err
+		}
+		return recordInstall()
+	}
+
+	// No native package manager for this distro; fall back to the
+	// bundled wrapper script.
 	wrapperPath, err := findWrapperScript()
 	if err != nil {
 		return fmt.Errorf("wrapper script not found: %v", err)
 	}
 
-	// Execute wrapper install command
-	cmd := exec.Command(wrapperPath, "install")
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	
-	return cmd.Run()
+	if err := runWrapperScript(wrapperPath, "install"); err != nil {
+		return err
+	}
+	return recordInstall()
+}
+
+// ensureXDGDirs creates the config, cache, and repo directories pak-sh
+// needs so they exist before the first install/build/repo operation.
+func ensureXDGDirs() error {
+	for _, dirFn := range []func() (string, error){
+		paths.ConfigHome,
+		paths.CacheHome,
+		paths.PackageCacheDir,
+		paths.RepoDir,
+	} {
+		dir, err := dirFn()
+		if err != nil {
+			return err
+		}
+		if err := paths.EnsureDir(dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordInstall writes the per-user config file back to disk, creating it
+// on first install so "status" and per-user overrides have somewhere
+// durable to live. The config holds no separate installed marker.
+func recordInstall() error {
+	cfg, err := paths.LoadConfig()
+	if err != nil {
+		return err
+	}
+	return cfg.Save()
 }
 
 func runPak(c *cli.Context) error {
@@ -118,48 +240,122 @@ func runPak(c *cli.Context) error {
 		return fmt.Errorf("wrapper script not found: %v", err)
 	}
 
-	// Build command with arguments
-	args := append([]string{"run"}, c.Args().Slice()...)
-	cmd := exec.Command(wrapperPath, args...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	cmd.Stdin = os.Stdin
-	
-	return cmd.Run()
+	return runWrapperScript(wrapperPath, append([]string{"run"}, c.Args().Slice()...)...)
 }
 
 func checkStatus(c *cli.Context) error {
 	color.Blue("🔍 Checking PAK.sh installation status...")
-	
-	// Find wrapper script
+
+	if configPath, err := paths.ConfigFile(); err == nil {
+		if _, err := os.Stat(configPath); err == nil {
+			color.Blue("Config: %s", configPath)
+		}
+	}
+
+	if mgr, ok := nativeManager(); ok {
+		installed, err := mgr.ListInstalled()
+		if err != nil {
+			return fmt.Errorf("list installed packages: %v", err)
+		}
+		for _, pkg := range installed {
+			if pkg == "pak-sh" {
+				color.Green("✔ pak-sh is installed (via %s)", mgr.Name())
+				return nil
+			}
+		}
+		color.Yellow("✘ pak-sh is not installed (via %s)", mgr.Name())
+		return nil
+	}
+
+	// No native package manager for this distro; fall back to the
+	// bundled wrapper script.
 	wrapperPath, err := findWrapperScript()
 	if err != nil {
 		return fmt.Errorf("wrapper script not found: %v", err)
 	}
 
-	// Execute wrapper status command
-	cmd := exec.Command(wrapperPath, "status")
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	
-	return cmd.Run()
+	return runWrapperScript(wrapperPath, "status")
 }
 
 func updatePak(c *cli.Context) error {
 	color.Blue("🔄 Updating PAK.sh...")
-	
-	// Find wrapper script
+
+	if mgr, ok := nativeManager(); ok {
+		color.Blue("Using %s (detected distro package manager)", mgr.Name())
+		return mgr.Upgrade("pak-sh")
+	}
+
+	// No native package manager for this distro; fall back to the
+	// bundled wrapper script.
 	wrapperPath, err := findWrapperScript()
 	if err != nil {
 		return fmt.Errorf("wrapper script not found: %v", err)
 	}
 
-	// Execute wrapper update command
-	cmd := exec.Command(wrapperPath, "update")
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	
-	return cmd.Run()
+	return runWrapperScript(wrapperPath, "update")
+}
+
+// nativeManager detects the host distro and returns its package Manager.
+// The second return value is false when the distro is unrecognized or
+// has no known package manager, in which case callers should fall back
+// to the bundled wrapper script.
+func nativeManager() (distro.Manager, bool) {
+	info, err := distro.Detect()
+	if err != nil || info.Family == "" {
+		return nil, false
+	}
+	mgr, err := distro.ManagerFor(info.Family)
+	if err != nil {
+		return nil, false
+	}
+	return mgr, true
+}
+
+func buildPak(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return fmt.Errorf("usage: pak-sh build <spec>")
+	}
+
+	spec, err := build.LoadSpec(c.Args().First())
+	if err != nil {
+		return err
+	}
+
+	var formats []build.Format
+	for _, f := range c.StringSlice("format") {
+		formats = append(formats, build.Format(strings.ToLower(f)))
+	}
+
+	cacheDir, err := paths.PackageCacheDir()
+	if err != nil {
+		return err
+	}
+	if err := paths.EnsureDir(cacheDir); err != nil {
+		return err
+	}
+
+	color.Blue("🔨 Building %s %s...", spec.Name, spec.Version)
+
+	if info, err := distro.Detect(); err == nil && info.Family != "" {
+		if deps := spec.ResolvedDepends(info.Family); len(deps) > 0 {
+			color.Blue("Resolved deps for %s: %s", info.Family, strings.Join(deps, ", "))
+		}
+	}
+
+	builder := build.NewBuilder(cacheDir)
+	artifacts, err := builder.Build(c.Context, spec, build.Options{
+		Formats: formats,
+		OutDir:  c.String("output"),
+		Clean:   c.Bool("clean"),
+	})
+	if err != nil {
+		return fmt.Errorf("build failed: %v", err)
+	}
+
+	for _, artifact := range artifacts {
+		color.Green("✔ built %s", artifact)
+	}
+	return nil
 }
 
 func showVersion(c *cli.Context) error {
@@ -170,38 +366,37 @@ func showVersion(c *cli.Context) error {
 }
 
 func findWrapperScript() (string, error) {
-	// Get the directory of the current executable
-	exe, err := os.Executable()
-	if err != nil {
-		return "", err
-	}
-	
-	exeDir := filepath.Dir(exe)
-	
-	// Look for pak-sh script in the same directory
-	wrapperPath := filepath.Join(exeDir, "pak-sh")
+	name := "pak-sh"
 	if runtime.GOOS == "windows" {
-		wrapperPath += ".exe"
-	}
-	
-	if _, err := os.Stat(wrapperPath); err == nil {
-		return wrapperPath, nil
+		name += ".exe"
 	}
-	
-	// Look for pak-sh script in current directory
-	currentDir, err := os.Getwd()
-	if err != nil {
-		return "", err
+
+	// Prefer an XDG data directory install (a script placed under
+	// $XDG_DATA_HOME/pak-sh or one of $XDG_DATA_DIRS) over anything
+	// bundled next to the binary.
+	if dataDirs, err := paths.DataDirs(); err == nil {
+		for _, dir := range dataDirs {
+			wrapperPath := filepath.Join(dir, name)
+			if _, err := os.Stat(wrapperPath); err == nil {
+				return wrapperPath, nil
+			}
+		}
 	}
-	
-	wrapperPath = filepath.Join(currentDir, "pak-sh")
-	if runtime.GOOS == "windows" {
-		wrapperPath += ".exe"
+
+	// Fall back to legacy locations: next to the executable, then cwd.
+	if exe, err := os.Executable(); err == nil {
+		wrapperPath := filepath.Join(filepath.Dir(exe), name)
+		if _, err := os.Stat(wrapperPath); err == nil {
+			return wrapperPath, nil
+		}
 	}
-	
-	if _, err := os.Stat(wrapperPath); err == nil {
-		return wrapperPath, nil
+
+	if currentDir, err := os.Getwd(); err == nil {
+		wrapperPath := filepath.Join(currentDir, name)
+		if _, err := os.Stat(wrapperPath); err == nil {
+			return wrapperPath, nil
+		}
 	}
-	
+
 	return "", fmt.Errorf("pak-sh wrapper script not found")
-} 
\ No newline at end of file
+}
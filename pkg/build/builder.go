@@ -0,0 +1,106 @@
+package build
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Format identifies a package artifact format Builder can emit.
+type Format string
+
+const (
+	FormatDeb    Format = "deb"
+	FormatRPM    Format = "rpm"
+	FormatAPK    Format = "apk"
+	FormatPacman Format = "pacman"
+)
+
+// Options controls a single Build invocation.
+type Options struct {
+	Formats []Format
+	OutDir  string
+	// Clean removes the sandbox working directory before running,
+	// forcing prepare/build/package to start from scratch.
+	Clean bool
+}
+
+// Builder resolves a Spec's sources, runs its prepare/build/package
+// stages in a sandboxed working directory, and emits artifacts in one or
+// more package formats.
+type Builder interface {
+	// Build runs all stages for spec and returns the paths to the
+	// artifacts produced, one per requested format.
+	Build(ctx context.Context, spec *Spec, opts Options) ([]string, error)
+}
+
+// NewBuilder returns the default Builder, which stages work under
+// baseDir (typically the caller's cache/build directory).
+func NewBuilder(baseDir string) Builder {
+	return &sandboxBuilder{baseDir: baseDir}
+}
+
+type sandboxBuilder struct {
+	baseDir string
+}
+
+func (b *sandboxBuilder) Build(ctx context.Context, spec *Spec, opts Options) ([]string, error) {
+	workDir := filepath.Join(b.baseDir, spec.Name+"-"+spec.Version)
+	if opts.Clean {
+		if err := os.RemoveAll(workDir); err != nil {
+			return nil, fmt.Errorf("clean work dir: %w", err)
+		}
+	}
+	if err := os.MkdirAll(workDir, 0o755); err != nil {
+		return nil, fmt.Errorf("create work dir: %w", err)
+	}
+
+	sandbox := newSandbox(workDir, spec)
+
+	if err := fetchSources(ctx, spec, workDir); err != nil {
+		return nil, fmt.Errorf("fetch sources: %w", err)
+	}
+	if spec.Prepare != "" {
+		if err := sandbox.Run(ctx, spec.Prepare); err != nil {
+			return nil, fmt.Errorf("prepare: %w", err)
+		}
+	}
+	if spec.Build != "" {
+		if err := sandbox.Run(ctx, spec.Build); err != nil {
+			return nil, fmt.Errorf("build: %w", err)
+		}
+	}
+	packageDir := filepath.Join(workDir, "pkg")
+	if err := os.MkdirAll(packageDir, 0o755); err != nil {
+		return nil, err
+	}
+	if spec.Package != "" {
+		if err := sandbox.Run(ctx, spec.Package); err != nil {
+			return nil, fmt.Errorf("package: %w", err)
+		}
+	}
+
+	formats := opts.Formats
+	if len(formats) == 0 {
+		formats = []Format{FormatDeb}
+	}
+
+	outDir := opts.OutDir
+	if outDir == "" {
+		outDir = workDir
+	}
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return nil, err
+	}
+
+	var artifacts []string
+	for _, format := range formats {
+		path, err := emit(spec, packageDir, outDir, format)
+		if err != nil {
+			return nil, fmt.Errorf("emit %s: %w", format, err)
+		}
+		artifacts = append(artifacts, path)
+	}
+	return artifacts, nil
+}
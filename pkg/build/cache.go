@@ -0,0 +1,185 @@
+package build
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Artifact is the cache manifest entry recorded alongside each built
+// package artifact.
+type Artifact struct {
+	Path    string    `json:"path"`
+	Hash    string    `json:"hash"` // content hash of spec + source URLs + build vars
+	Format  Format    `json:"format"`
+	Version string    `json:"version"`
+	BuiltAt time.Time `json:"built_at"`
+	SHA256  string    `json:"sha256"` // checksum of the artifact itself
+}
+
+// SpecHash returns a content hash of spec's build-relevant fields: its
+// own contents plus source URLs, stable across rebuilds that wouldn't
+// change the resulting artifact.
+func SpecHash(spec *Spec, vars map[string]string) (string, error) {
+	data, err := os.ReadFile(spec.Path)
+	if err != nil {
+		return "", fmt.Errorf("hash spec: %w", err)
+	}
+
+	h := sha256.New()
+	h.Write(data)
+	for _, src := range spec.Sources {
+		h.Write([]byte(src.URL))
+	}
+
+	keys := make([]string, 0, len(vars))
+	for k := range vars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		h.Write([]byte(k + "=" + vars[k]))
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Cache manages the on-disk artifact cache under a single
+// $XDG_CACHE_HOME/pak-sh/pkgs directory: one manifest.json recording
+// metadata for every cached artifact, keyed by spec hash.
+type Cache struct {
+	dir string
+}
+
+// NewCache returns a Cache rooted at dir (typically
+// paths.PackageCacheDir()).
+func NewCache(dir string) *Cache {
+	return &Cache{dir: dir}
+}
+
+func (c *Cache) manifestPath() string {
+	return filepath.Join(c.dir, "manifest.json")
+}
+
+func (c *Cache) loadManifest() (map[string]Artifact, error) {
+	data, err := os.ReadFile(c.manifestPath())
+	if os.IsNotExist(err) {
+		return map[string]Artifact{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	manifest := map[string]Artifact{}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func (c *Cache) saveManifest(manifest map[string]Artifact) error {
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.manifestPath(), data, 0o644)
+}
+
+// Lookup returns the cached artifact for hash, and whether it is
+// present on disk and at least version minVersion.
+func (c *Cache) Lookup(hash, minVersion string) (Artifact, bool, error) {
+	manifest, err := c.loadManifest()
+	if err != nil {
+		return Artifact{}, false, err
+	}
+	artifact, ok := manifest[hash]
+	if !ok {
+		return Artifact{}, false, nil
+	}
+	if _, err := os.Stat(artifact.Path); err != nil {
+		return Artifact{}, false, nil
+	}
+	if versionLess(artifact.Version, minVersion) {
+		return Artifact{}, false, nil
+	}
+	return artifact, true, nil
+}
+
+// Record stores metadata for a freshly built artifact under hash,
+// computing its SHA256 from the file at artifact.Path when unset.
+func (c *Cache) Record(hash string, artifact Artifact) error {
+	if artifact.SHA256 == "" {
+		f, err := os.Open(artifact.Path)
+		if err != nil {
+			return err
+		}
+		h := sha256.New()
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+		artifact.SHA256 = hex.EncodeToString(h.Sum(nil))
+	}
+	manifest, err := c.loadManifest()
+	if err != nil {
+		return err
+	}
+	manifest[hash] = artifact
+	return c.saveManifest(manifest)
+}
+
+// Prune removes every cached artifact (and its manifest entry) built
+// before the given cutoff.
+func (c *Cache) Prune(olderThan time.Duration) (int, error) {
+	manifest, err := c.loadManifest()
+	if err != nil {
+		return 0, err
+	}
+	cutoff := time.Now().Add(-olderThan)
+
+	removed := 0
+	for hash, artifact := range manifest {
+		if artifact.BuiltAt.After(cutoff) {
+			continue
+		}
+		os.Remove(artifact.Path)
+		delete(manifest, hash)
+		removed++
+	}
+
+	return removed, c.saveManifest(manifest)
+}
+
+// versionLess reports whether a is an older version than b, comparing
+// dot-separated segments numerically when both sides are numeric
+// (so "10.0" sorts after "9.0") and falling back to a string compare
+// per segment otherwise. This covers plain semver and date-based
+// version strings alike.
+func versionLess(a, b string) bool {
+	as := strings.Split(a, ".")
+	bs := strings.Split(b, ".")
+
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if as[i] == bs[i] {
+			continue
+		}
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		if aErr == nil && bErr == nil {
+			return an < bn
+		}
+		return as[i] < bs[i]
+	}
+	return len(as) < len(bs)
+}
@@ -0,0 +1,23 @@
+package build
+
+import "testing"
+
+func TestVersionLess(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want bool
+	}{
+		{"9.0", "10.0", true},
+		{"10.0", "9.0", false},
+		{"1.2.3", "1.2.10", true},
+		{"1.2.10", "1.2.3", false},
+		{"1.2", "1.2", false},
+		{"1.2", "1.2.1", true},
+		{"2024.01.01", "2024.02.01", true},
+	}
+	for _, c := range cases {
+		if got := versionLess(c.a, c.b); got != c.want {
+			t.Errorf("versionLess(%q, %q) = %v, want %v", c.a, c.b, got, c.want)
+		}
+	}
+}
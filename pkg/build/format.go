@@ -0,0 +1,73 @@
+package build
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/goreleaser/nfpm/v2"
+	"github.com/goreleaser/nfpm/v2/files"
+
+	_ "github.com/goreleaser/nfpm/v2/apk"
+	_ "github.com/goreleaser/nfpm/v2/arch"
+	_ "github.com/goreleaser/nfpm/v2/deb"
+	_ "github.com/goreleaser/nfpm/v2/rpm"
+)
+
+// packagerName maps our Format to the name nfpm registers its packagers
+// under.
+func packagerName(f Format) (string, string, error) {
+	switch f {
+	case FormatDeb:
+		return "deb", "deb", nil
+	case FormatRPM:
+		return "rpm", "rpm", nil
+	case FormatAPK:
+		return "apk", "apk", nil
+	case FormatPacman:
+		return "archlinux", "pkg.tar.zst", nil
+	default:
+		return "", "", fmt.Errorf("unsupported format %q", f)
+	}
+}
+
+// emit packages the contents of packageDir as a single artifact in the
+// requested format, writing it into outDir.
+func emit(spec *Spec, packageDir, outDir string, format Format) (string, error) {
+	packagerName, ext, err := packagerName(format)
+	if err != nil {
+		return "", err
+	}
+
+	info := nfpm.WithDefaults(&nfpm.Info{
+		Name:        spec.Name,
+		Version:     spec.Version,
+		Description: spec.Description,
+		Maintainer:  spec.Maintainer,
+		License:     spec.License,
+		Arch:        runtime.GOARCH,
+		Overridables: nfpm.Overridables{
+			Contents: files.Contents{
+				{Source: packageDir, Destination: "/", Type: "tree"},
+			},
+		},
+	})
+
+	packager, err := nfpm.Get(packagerName)
+	if err != nil {
+		return "", err
+	}
+
+	outPath := filepath.Join(outDir, fmt.Sprintf("%s-%s.%s", spec.Name, spec.Version, ext))
+	out, err := os.Create(outPath)
+	if err != nil {
+		return "", err
+	}
+	defer out.Close()
+
+	if err := packager.Package(info, out); err != nil {
+		return "", err
+	}
+	return outPath, nil
+}
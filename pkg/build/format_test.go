@@ -0,0 +1,28 @@
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEmitProducesArtifactForEveryFormat(t *testing.T) {
+	packageDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(packageDir, "hello.txt"), []byte("hi\n"), 0o644); err != nil {
+		t.Fatalf("write fixture file: %v", err)
+	}
+	outDir := t.TempDir()
+
+	spec := &Spec{Name: "pak-test", Version: "1.0.0", Description: "a test package"}
+
+	for _, format := range []Format{FormatDeb, FormatRPM, FormatAPK, FormatPacman} {
+		path, err := emit(spec, packageDir, outDir, format)
+		if err != nil {
+			t.Errorf("emit(%s) returned error: %v", format, err)
+			continue
+		}
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("emit(%s) artifact missing at %s: %v", format, path, err)
+		}
+	}
+}
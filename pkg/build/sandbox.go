@@ -0,0 +1,39 @@
+package build
+
+import (
+	"net/url"
+
+	"github.com/cyber-boost/pak/pkg/sandbox"
+)
+
+// newSandbox returns a sandbox.Sandbox confined to workDir: writes
+// outside it are refused, setuid/setgid binaries can't be exec'd, and
+// network access is limited to the hosts spec itself declares via its
+// Sources. Specs are pulled from arbitrary, untrusted git repos (see
+// pkg/repos), so prepare/build/package must not be trusted to reach
+// hosts the spec never told us about.
+func newSandbox(workDir string, spec *Spec) *sandbox.Sandbox {
+	return sandbox.New(sandbox.Policy{
+		WriteDir:     workDir,
+		ForbidSetuid: true,
+		AllowedHosts: sourceHosts(spec),
+	}, workDir, nil)
+}
+
+// sourceHosts returns the deduplicated set of hostnames referenced by
+// spec's declared Sources, used as the sandbox's network allow-list.
+func sourceHosts(spec *Spec) []string {
+	seen := map[string]bool{}
+	hosts := []string{}
+	for _, src := range spec.Sources {
+		u, err := url.Parse(src.URL)
+		if err != nil || u.Hostname() == "" {
+			continue
+		}
+		if !seen[u.Hostname()] {
+			seen[u.Hostname()] = true
+			hosts = append(hosts, u.Hostname())
+		}
+	}
+	return hosts
+}
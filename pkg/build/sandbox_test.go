@@ -0,0 +1,33 @@
+package build
+
+import "testing"
+
+func TestSourceHosts(t *testing.T) {
+	spec := &Spec{
+		Sources: []Source{
+			{URL: "https://example.com/a.tar.gz"},
+			{URL: "https://example.com/b.tar.gz"},
+			{URL: "https://other.example.org/c.tar.gz"},
+		},
+	}
+	hosts := sourceHosts(spec)
+	if len(hosts) != 2 {
+		t.Fatalf("sourceHosts = %v, want 2 deduplicated hosts", hosts)
+	}
+	want := map[string]bool{"example.com": true, "other.example.org": true}
+	for _, h := range hosts {
+		if !want[h] {
+			t.Errorf("unexpected host %q in %v", h, hosts)
+		}
+	}
+}
+
+func TestSourceHostsEmptyForNoSources(t *testing.T) {
+	hosts := sourceHosts(&Spec{})
+	if hosts == nil {
+		t.Error("sourceHosts(no sources) = nil, want non-nil empty slice so the sandbox still defaults to deny")
+	}
+	if len(hosts) != 0 {
+		t.Errorf("sourceHosts(no sources) = %v, want empty", hosts)
+	}
+}
@@ -0,0 +1,96 @@
+package build
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// fetchSources downloads every source listed in spec into workDir,
+// verifying each against its checksum when one is provided.
+func fetchSources(ctx context.Context, spec *Spec, workDir string) error {
+	for _, src := range spec.Sources {
+		dest := src.Dest
+		if dest == "" {
+			dest = filepath.Base(src.URL)
+		}
+		destPath, err := safeJoin(workDir, dest)
+		if err != nil {
+			return fmt.Errorf("source dest %q: %w", dest, err)
+		}
+
+		if err := downloadFile(ctx, src.URL, destPath); err != nil {
+			return fmt.Errorf("%s: %w", src.URL, err)
+		}
+		if src.Checksum != "" {
+			if err := verifyChecksum(destPath, src.Checksum); err != nil {
+				return fmt.Errorf("%s: %w", src.URL, err)
+			}
+		}
+	}
+	return nil
+}
+
+// safeJoin joins base and rel, refusing any rel that would resolve
+// outside base (absolute paths, "../" escapes). Spec sources are
+// attacker-controlled content pulled from arbitrary git repos, so dest
+// must never be allowed to write outside the build sandbox.
+func safeJoin(base, rel string) (string, error) {
+	if filepath.IsAbs(rel) {
+		return "", fmt.Errorf("must be a relative path")
+	}
+	joined := filepath.Join(base, rel)
+	baseClean := filepath.Clean(base)
+	if joined != baseClean && !strings.HasPrefix(joined, baseClean+string(filepath.Separator)) {
+		return "", fmt.Errorf("escapes build directory")
+	}
+	return joined, nil
+}
+
+func downloadFile(ctx context.Context, url, destPath string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	out, err := os.Create(destPath)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, resp.Body)
+	return err
+}
+
+func verifyChecksum(path, want string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+	got := hex.EncodeToString(h.Sum(nil))
+	if got != want {
+		return fmt.Errorf("checksum mismatch: want %s, got %s", want, got)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package build
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSafeJoinRejectsEscape(t *testing.T) {
+	base := "/tmp/pak-build-workdir"
+	cases := []string{
+		"../../../../etc/cron.d/evil",
+		"../outside",
+		"/etc/passwd",
+	}
+	for _, rel := range cases {
+		if _, err := safeJoin(base, rel); err == nil {
+			t.Errorf("safeJoin(%q, %q) = nil error, want rejection", base, rel)
+		}
+	}
+}
+
+func TestSafeJoinAllowsWithinBase(t *testing.T) {
+	base := "/tmp/pak-build-workdir"
+	got, err := safeJoin(base, "src/archive.tar.gz")
+	if err != nil {
+		t.Fatalf("safeJoin returned error for valid dest: %v", err)
+	}
+	want := filepath.Join(base, "src/archive.tar.gz")
+	if got != want {
+		t.Errorf("safeJoin = %q, want %q", got, want)
+	}
+}
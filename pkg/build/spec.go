@@ -0,0 +1,113 @@
+package build
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Source describes a single upstream artifact that must be fetched before
+// the build stage runs.
+type Source struct {
+	URL      string `yaml:"url"`
+	Checksum string `yaml:"checksum,omitempty"`
+	Dest     string `yaml:"dest,omitempty"`
+}
+
+// Depends captures per-distro-family override lists. The base list always
+// applies; the family-specific list is appended on top of it once the
+// target distro has been resolved.
+type Depends struct {
+	Base     []string `yaml:"base,omitempty"`
+	Arch     []string `yaml:"arch,omitempty"`
+	Debian   []string `yaml:"debian,omitempty"`
+	RHELLike []string `yaml:"rhel_like,omitempty"`
+}
+
+// Spec is the declarative package build description consumed by Builder.
+// It can be authored as YAML (the default) or as a PAK build shell script
+// decoded with mvdan.cc/sh/v3 (see spec_shell.go).
+type Spec struct {
+	Name        string   `yaml:"name"`
+	Version     string   `yaml:"version"`
+	Description string   `yaml:"description,omitempty"`
+	Maintainer  string   `yaml:"maintainer,omitempty"`
+	License     string   `yaml:"license,omitempty"`
+	Sources     []Source `yaml:"sources,omitempty"`
+	Depends     Depends  `yaml:"deps,omitempty"`
+	// Provides and Replaces are the package names this spec provides
+	// or replaces, as recorded in the repo index (pkg/repos).
+	Provides []string `yaml:"provides,omitempty"`
+	Replaces []string `yaml:"replaces,omitempty"`
+
+	Prepare string `yaml:"prepare,omitempty"`
+	Build   string `yaml:"build,omitempty"`
+	Package string `yaml:"package,omitempty"`
+
+	// Path is the spec file this Spec was loaded from. Set by LoadSpec.
+	Path string `yaml:"-"`
+}
+
+// LoadSpec reads a package spec from path. Files ending in .sh are decoded
+// as PAK build scripts; everything else is parsed as YAML.
+func LoadSpec(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read spec: %w", err)
+	}
+
+	var spec *Spec
+	if isShellSpec(path) {
+		spec, err = parseShellSpec(data)
+	} else {
+		spec = &Spec{}
+		err = yaml.Unmarshal(data, spec)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse spec %s: %w", path, err)
+	}
+
+	if spec.Name == "" {
+		return nil, fmt.Errorf("spec %s: missing name", path)
+	}
+	if err := validatePathSegment(spec.Name); err != nil {
+		return nil, fmt.Errorf("spec %s: name %q: %w", path, spec.Name, err)
+	}
+	if err := validatePathSegment(spec.Version); err != nil {
+		return nil, fmt.Errorf("spec %s: version %q: %w", path, spec.Version, err)
+	}
+	spec.Path = path
+	return spec, nil
+}
+
+// validatePathSegment rejects values that could escape the single path
+// segment they're joined into (build/cache directory names derived from
+// Name/Version). Specs are attacker-controlled content pulled from
+// arbitrary git repos (see pkg/repos), so these must never be allowed to
+// contain a path separator or be "..".
+func validatePathSegment(s string) error {
+	if s == "" {
+		return nil
+	}
+	if strings.ContainsAny(s, "/\\") || s == ".." {
+		return fmt.Errorf("must not contain a path separator or be \"..\"")
+	}
+	return nil
+}
+
+// ResolvedDepends merges the base dependency list with the override list
+// for the given distro family ("arch", "debian", "rhel_like").
+func (s *Spec) ResolvedDepends(family string) []string {
+	out := append([]string{}, s.Depends.Base...)
+	switch family {
+	case "arch":
+		out = append(out, s.Depends.Arch...)
+	case "debian":
+		out = append(out, s.Depends.Debian...)
+	case "rhel_like":
+		out = append(out, s.Depends.RHELLike...)
+	}
+	return out
+}
@@ -0,0 +1,104 @@
+package build
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"mvdan.cc/sh/v3/syntax"
+)
+
+// isShellSpec reports whether path should be decoded as a PAK build script
+// rather than YAML.
+func isShellSpec(path string) bool {
+	return strings.HasSuffix(path, ".sh")
+}
+
+// parseShellSpec decodes a PAK build script: a plain shell file that sets
+// well-known variables (name, version, description, ...) and defines
+// prepare()/build()/package() functions. Spec files come from arbitrary,
+// untrusted git repos (see pkg/repos), so this never interprets the
+// script: it only walks the parsed AST to pull out literal top-level
+// variable assignments and verbatim function bodies. No shell command,
+// redirect, or substitution in the file is ever executed.
+func parseShellSpec(data []byte) (*Spec, error) {
+	file, err := syntax.NewParser().Parse(bytes.NewReader(data), "spec.sh")
+	if err != nil {
+		return nil, fmt.Errorf("parse shell spec: %w", err)
+	}
+
+	vars := map[string]string{}
+	funcs := map[string]string{}
+
+	for _, stmt := range file.Stmts {
+		call, ok := stmt.Cmd.(*syntax.CallExpr)
+		if ok && len(call.Args) == 0 {
+			for _, assign := range call.Assigns {
+				if assign.Name == nil || assign.Value == nil {
+					continue
+				}
+				if lit, ok := literalWord(assign.Value); ok {
+					vars[assign.Name.Value] = lit
+				}
+			}
+			continue
+		}
+		if fn, ok := stmt.Cmd.(*syntax.FuncDecl); ok {
+			var buf bytes.Buffer
+			if err := syntax.NewPrinter().Print(&buf, fn.Body); err == nil {
+				funcs[fn.Name.Value] = buf.String()
+			}
+		}
+	}
+
+	spec := &Spec{
+		Name:        vars["name"],
+		Version:     vars["version"],
+		Description: vars["description"],
+		Maintainer:  vars["maintainer"],
+		License:     vars["license"],
+		Depends: Depends{
+			Base:     splitWords(vars["deps"]),
+			Arch:     splitWords(vars["deps_arch"]),
+			Debian:   splitWords(vars["deps_debian"]),
+			RHELLike: splitWords(vars["deps_rhel_like"]),
+		},
+		Provides: splitWords(vars["provides"]),
+		Replaces: splitWords(vars["replaces"]),
+		Prepare:  funcs["prepare"],
+		Build:    funcs["build"],
+		Package:  funcs["package"],
+	}
+	return spec, nil
+}
+
+func splitWords(s string) []string {
+	return strings.Fields(s)
+}
+
+// literalWord returns a word's value if, and only if, it is composed
+// entirely of literal text or quoted literal text (no parameter
+// expansion, command substitution, or arithmetic) — so extracting it
+// never needs to run anything.
+func literalWord(w *syntax.Word) (string, bool) {
+	var sb strings.Builder
+	for _, part := range w.Parts {
+		switch p := part.(type) {
+		case *syntax.Lit:
+			sb.WriteString(p.Value)
+		case *syntax.SglQuoted:
+			sb.WriteString(p.Value)
+		case *syntax.DblQuoted:
+			for _, inner := range p.Parts {
+				lit, ok := inner.(*syntax.Lit)
+				if !ok {
+					return "", false
+				}
+				sb.WriteString(lit.Value)
+			}
+		default:
+			return "", false
+		}
+	}
+	return sb.String(), true
+}
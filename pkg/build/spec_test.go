@@ -0,0 +1,41 @@
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeSpec(t *testing.T, yaml string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "pkg.yaml")
+	if err := os.WriteFile(path, []byte(yaml), 0o644); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+	return path
+}
+
+func TestLoadSpecRejectsPathTraversalInNameOrVersion(t *testing.T) {
+	cases := []string{
+		"name: ../../../../tmp/pwned\nversion: 1.0\n",
+		"name: pwned\nversion: ../../escape\n",
+		"name: \"a/b\"\nversion: 1.0\n",
+	}
+	for _, yaml := range cases {
+		path := writeSpec(t, yaml)
+		if _, err := LoadSpec(path); err == nil {
+			t.Errorf("LoadSpec(%q) = nil error, want rejection of path-traversal name/version", yaml)
+		}
+	}
+}
+
+func TestLoadSpecAllowsOrdinaryNameAndVersion(t *testing.T) {
+	path := writeSpec(t, "name: hello-world\nversion: 1.2.3\n")
+	spec, err := LoadSpec(path)
+	if err != nil {
+		t.Fatalf("LoadSpec: %v", err)
+	}
+	if spec.Name != "hello-world" || spec.Version != "1.2.3" {
+		t.Errorf("LoadSpec = %+v, want name=hello-world version=1.2.3", spec)
+	}
+}
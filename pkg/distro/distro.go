@@ -0,0 +1,93 @@
+// Package distro detects the host Linux distribution from /etc/os-release
+// and classifies it into a package-manager family so callers can pick the
+// right Manager implementation without special-casing every ID.
+package distro
+
+import (
+	"bufio"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// Info describes the detected host distribution.
+type Info struct {
+	ID        string // e.g. "ubuntu", "fedora", "arch"
+	IDLike    []string
+	VersionID string
+	// Family is the package-manager family this distro belongs to:
+	// "debian", "rhel_like", "arch", "alpine", "suse", or "" if unknown.
+	Family string
+}
+
+var familyByID = map[string]string{
+	"ubuntu":   "debian",
+	"debian":   "debian",
+	"mint":     "debian",
+	"fedora":   "rhel_like",
+	"rhel":     "rhel_like",
+	"centos":   "rhel_like",
+	"rocky":    "rhel_like",
+	"alma":     "rhel_like",
+	"arch":     "arch",
+	"manjaro":  "arch",
+	"alpine":   "alpine",
+	"opensuse": "suse",
+	"suse":     "suse",
+}
+
+// Detect parses /etc/os-release and returns the host's distro Info. On
+// non-Linux platforms, or when os-release can't be read, it returns a
+// zero-value Info with an empty Family so callers can fall back to a
+// generic path.
+func Detect() (Info, error) {
+	if runtime.GOOS != "linux" {
+		return Info{}, nil
+	}
+
+	f, err := os.Open("/etc/os-release")
+	if err != nil {
+		return Info{}, err
+	}
+	defer f.Close()
+
+	return parseOSRelease(f), nil
+}
+
+func parseOSRelease(f *os.File) Info {
+	values := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		k, v, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		values[k] = strings.Trim(v, `"`)
+	}
+
+	info := Info{
+		ID:        values["ID"],
+		VersionID: values["VERSION_ID"],
+	}
+	if like := values["ID_LIKE"]; like != "" {
+		info.IDLike = strings.Fields(like)
+	}
+	info.Family = resolveFamily(info.ID, info.IDLike)
+	return info
+}
+
+func resolveFamily(id string, idLike []string) string {
+	if family, ok := familyByID[id]; ok {
+		return family
+	}
+	for _, like := range idLike {
+		if family, ok := familyByID[like]; ok {
+			return family
+		}
+	}
+	return ""
+}
@@ -0,0 +1,184 @@
+package distro
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// Manager drives a host's native package manager.
+type Manager interface {
+	// Name is the package manager's command name (e.g. "apt-get").
+	Name() string
+	Install(pkgs ...string) error
+	Remove(pkgs ...string) error
+	Upgrade(pkgs ...string) error
+	ListInstalled() ([]string, error)
+}
+
+// ManagerFor returns the Manager for the given distro family, or an error
+// if the family is unrecognized or has no known package manager.
+func ManagerFor(family string) (Manager, error) {
+	switch family {
+	case "debian":
+		return &aptManager{}, nil
+	case "rhel_like":
+		return &dnfManager{}, nil
+	case "arch":
+		return &pacmanManager{}, nil
+	case "alpine":
+		return &apkManager{}, nil
+	case "suse":
+		return &zypperManager{}, nil
+	default:
+		return nil, fmt.Errorf("no package manager known for distro family %q", family)
+	}
+}
+
+func run(name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+	return cmd.Run()
+}
+
+func runOutput(name string, args ...string) ([]byte, error) {
+	cmd := exec.Command(name, args...)
+	return cmd.Output()
+}
+
+type aptManager struct{}
+
+func (m *aptManager) Name() string { return "apt-get" }
+
+func (m *aptManager) Install(pkgs ...string) error {
+	return run("apt-get", append([]string{"install", "-y"}, pkgs...)...)
+}
+
+func (m *aptManager) Remove(pkgs ...string) error {
+	return run("apt-get", append([]string{"remove", "-y"}, pkgs...)...)
+}
+
+func (m *aptManager) Upgrade(pkgs ...string) error {
+	if len(pkgs) == 0 {
+		return run("apt-get", "upgrade", "-y")
+	}
+	return run("apt-get", append([]string{"install", "--only-upgrade", "-y"}, pkgs...)...)
+}
+
+func (m *aptManager) ListInstalled() ([]string, error) {
+	out, err := runOutput("dpkg-query", "-f", "${Package}\n", "-W")
+	if err != nil {
+		return nil, err
+	}
+	return splitLines(out), nil
+}
+
+type dnfManager struct{}
+
+func (m *dnfManager) Name() string { return "dnf" }
+
+func (m *dnfManager) Install(pkgs ...string) error {
+	return run("dnf", append([]string{"install", "-y"}, pkgs...)...)
+}
+
+func (m *dnfManager) Remove(pkgs ...string) error {
+	return run("dnf", append([]string{"remove", "-y"}, pkgs...)...)
+}
+
+func (m *dnfManager) Upgrade(pkgs ...string) error {
+	if len(pkgs) == 0 {
+		return run("dnf", "upgrade", "-y")
+	}
+	return run("dnf", append([]string{"upgrade", "-y"}, pkgs...)...)
+}
+
+func (m *dnfManager) ListInstalled() ([]string, error) {
+	out, err := runOutput("rpm", "-qa", "--qf", "%{NAME}\n")
+	if err != nil {
+		return nil, err
+	}
+	return splitLines(out), nil
+}
+
+type pacmanManager struct{}
+
+func (m *pacmanManager) Name() string { return "pacman" }
+
+func (m *pacmanManager) Install(pkgs ...string) error {
+	return run("pacman", append([]string{"-S", "--noconfirm"}, pkgs...)...)
+}
+
+func (m *pacmanManager) Remove(pkgs ...string) error {
+	return run("pacman", append([]string{"-R", "--noconfirm"}, pkgs...)...)
+}
+
+func (m *pacmanManager) Upgrade(pkgs ...string) error {
+	if len(pkgs) == 0 {
+		return run("pacman", "-Syu", "--noconfirm")
+	}
+	return run("pacman", append([]string{"-S", "--noconfirm"}, pkgs...)...)
+}
+
+func (m *pacmanManager) ListInstalled() ([]string, error) {
+	out, err := runOutput("pacman", "-Qq")
+	if err != nil {
+		return nil, err
+	}
+	return splitLines(out), nil
+}
+
+type apkManager struct{}
+
+func (m *apkManager) Name() string { return "apk" }
+
+func (m *apkManager) Install(pkgs ...string) error {
+	return run("apk", append([]string{"add"}, pkgs...)...)
+}
+
+func (m *apkManager) Remove(pkgs ...string) error {
+	return run("apk", append([]string{"del"}, pkgs...)...)
+}
+
+func (m *apkManager) Upgrade(pkgs ...string) error {
+	if len(pkgs) == 0 {
+		return run("apk", "upgrade")
+	}
+	return run("apk", append([]string{"add", "-u"}, pkgs...)...)
+}
+
+func (m *apkManager) ListInstalled() ([]string, error) {
+	out, err := runOutput("apk", "info")
+	if err != nil {
+		return nil, err
+	}
+	return splitLines(out), nil
+}
+
+type zypperManager struct{}
+
+func (m *zypperManager) Name() string { return "zypper" }
+
+func (m *zypperManager) Install(pkgs ...string) error {
+	return run("zypper", append([]string{"install", "-y"}, pkgs...)...)
+}
+
+func (m *zypperManager) Remove(pkgs ...string) error {
+	return run("zypper", append([]string{"remove", "-y"}, pkgs...)...)
+}
+
+func (m *zypperManager) Upgrade(pkgs ...string) error {
+	if len(pkgs) == 0 {
+		return run("zypper", "update", "-y")
+	}
+	return run("zypper", append([]string{"update", "-y"}, pkgs...)...)
+}
+
+func (m *zypperManager) ListInstalled() ([]string, error) {
+	out, err := runOutput("rpm", "-qa", "--qf", "%{NAME}\n")
+	if err != nil {
+		return nil, err
+	}
+	return splitLines(out), nil
+}
@@ -0,0 +1,75 @@
+package paths
+
+import (
+	"os"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// RepoEntry is one configured remote package-spec repository.
+type RepoEntry struct {
+	Name string `toml:"name"`
+	URL  string `toml:"url"`
+}
+
+// Config is the persistent pak-sh configuration stored at ConfigFile.
+type Config struct {
+	DefaultFormat string      `toml:"default_format,omitempty"`
+	Repos         []RepoEntry `toml:"repos,omitempty"`
+
+	// SandboxAllowedHosts, when non-nil, restricts the network hosts
+	// the wrapper-script sandbox (pkg/sandbox, via wrapper.go) may
+	// contact to this list, denying everything else by default. Unset
+	// (nil) leaves exec of other binaries unrestricted, but curl/wget/git
+	// targets are still refused since no host is allowed.
+	SandboxAllowedHosts []string `toml:"sandbox_allowed_hosts,omitempty"`
+	// SandboxAllowCommands extends the wrapper-script sandbox's exec
+	// allow-list beyond the built-in build tools, once
+	// SandboxAllowedHosts has switched it to default-deny.
+	SandboxAllowCommands []string `toml:"sandbox_allow_commands,omitempty"`
+}
+
+// LoadConfig reads the config file, returning a zero-value Config if it
+// doesn't exist yet.
+func LoadConfig() (*Config, error) {
+	path, err := ConfigFile()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{}
+	if err := toml.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Save writes cfg to ConfigFile, creating ConfigHome if necessary.
+func (c *Config) Save() error {
+	dir, err := ConfigHome()
+	if err != nil {
+		return err
+	}
+	if err := EnsureDir(dir); err != nil {
+		return err
+	}
+
+	path, err := ConfigFile()
+	if err != nil {
+		return err
+	}
+
+	data, err := toml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
@@ -0,0 +1,126 @@
+// Package paths resolves pak-sh's on-disk layout according to the XDG
+// Base Directory Specification, so config, cache, and data live in the
+// locations users (and other XDG-aware tools) expect them.
+package paths
+
+import (
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+)
+
+const appName = "pak-sh"
+
+// ConfigHome returns $XDG_CONFIG_HOME/pak-sh, defaulting to
+// ~/.config/pak-sh.
+func ConfigHome() (string, error) {
+	return xdgHome("XDG_CONFIG_HOME", ".config")
+}
+
+// DataHome returns $XDG_DATA_HOME/pak-sh, defaulting to
+// ~/.local/share/pak-sh.
+func DataHome() (string, error) {
+	return xdgHome("XDG_DATA_HOME", filepath.Join(".local", "share"))
+}
+
+// CacheHome returns $XDG_CACHE_HOME/pak-sh, defaulting to
+// ~/.cache/pak-sh.
+func CacheHome() (string, error) {
+	return xdgHome("XDG_CACHE_HOME", ".cache")
+}
+
+// ConfigFile returns the path to the persistent config.toml file under
+// ConfigHome.
+func ConfigFile() (string, error) {
+	dir, err := ConfigHome()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.toml"), nil
+}
+
+// PackageCacheDir returns the directory built package artifacts are
+// cached under, within CacheHome.
+func PackageCacheDir() (string, error) {
+	dir, err := CacheHome()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "pkgs"), nil
+}
+
+// RepoDir returns the directory cloned package-spec repositories live
+// under, within DataHome.
+func RepoDir() (string, error) {
+	dir, err := DataHome()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "repos"), nil
+}
+
+// ConfigDirs returns the search path for read-only config directories:
+// ConfigHome followed by $XDG_CONFIG_DIRS (default /etc/xdg), each
+// suffixed with /pak-sh.
+func ConfigDirs() ([]string, error) {
+	home, err := ConfigHome()
+	if err != nil {
+		return nil, err
+	}
+	dirs := []string{home}
+	extra := os.Getenv("XDG_CONFIG_DIRS")
+	if extra == "" {
+		extra = "/etc/xdg"
+	}
+	for _, d := range filepath.SplitList(extra) {
+		dirs = append(dirs, filepath.Join(d, appName))
+	}
+	return dirs, nil
+}
+
+// DataDirs returns the search path for read-only data directories:
+// DataHome followed by $XDG_DATA_DIRS (default /usr/local/share:/usr/share),
+// each suffixed with /pak-sh.
+func DataDirs() ([]string, error) {
+	home, err := DataHome()
+	if err != nil {
+		return nil, err
+	}
+	dirs := []string{home}
+	extra := os.Getenv("XDG_DATA_DIRS")
+	if extra == "" {
+		extra = "/usr/local/share:/usr/share"
+	}
+	for _, d := range filepath.SplitList(extra) {
+		dirs = append(dirs, filepath.Join(d, appName))
+	}
+	return dirs, nil
+}
+
+// EnsureDir creates dir (and any parents) if it doesn't already exist.
+func EnsureDir(dir string) error {
+	return os.MkdirAll(dir, 0o755)
+}
+
+func xdgHome(envVar, fallbackSuffix string) (string, error) {
+	if v := os.Getenv(envVar); v != "" {
+		return filepath.Join(v, appName), nil
+	}
+	home, err := homeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, fallbackSuffix, appName), nil
+}
+
+func homeDir() (string, error) {
+	if home := os.Getenv("HOME"); strings.TrimSpace(home) != "" {
+		return home, nil
+	}
+	u, err := user.Current()
+	if err != nil {
+		return "", err
+	}
+	return u.HomeDir, nil
+}
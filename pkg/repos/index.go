@@ -0,0 +1,143 @@
+package repos
+
+import (
+	"database/sql"
+	"fmt"
+
+	_ "modernc.org/sqlite"
+)
+
+// Index is the local SQLite database of every spec discovered across
+// configured repos.
+type Index struct {
+	db *sql.DB
+}
+
+// OpenIndex opens (creating if necessary) the index database at path.
+func OpenIndex(path string) (*Index, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &Index{db: db}, nil
+}
+
+func (idx *Index) Close() error {
+	return idx.db.Close()
+}
+
+const schema = `
+CREATE TABLE IF NOT EXISTS packages (
+	name         TEXT NOT NULL,
+	version      TEXT NOT NULL,
+	description  TEXT,
+	provides     TEXT,
+	replaces     TEXT,
+	depends      TEXT,
+	repo         TEXT NOT NULL,
+	spec_path    TEXT NOT NULL,
+	content_hash TEXT NOT NULL,
+	PRIMARY KEY (repo, spec_path)
+);
+CREATE INDEX IF NOT EXISTS idx_packages_name ON packages(name);
+`
+
+// Upsert inserts or updates a package entry.
+func (idx *Index) Upsert(p Package) error {
+	_, err := idx.db.Exec(`
+		INSERT INTO packages (name, version, description, provides, replaces, depends, repo, spec_path, content_hash)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(repo, spec_path) DO UPDATE SET
+			name=excluded.name, version=excluded.version, description=excluded.description,
+			provides=excluded.provides, replaces=excluded.replaces, depends=excluded.depends,
+			content_hash=excluded.content_hash`,
+		p.Name, p.Version, p.Description, p.Provides, p.Replaces, p.Depends, p.Repo, p.SpecPath, p.ContentHash)
+	return err
+}
+
+// ContentHash returns the previously recorded content hash for a spec
+// path, or "" if it isn't indexed yet. Used by Refresh to skip unchanged
+// entries.
+func (idx *Index) ContentHash(repo, specPath string) (string, error) {
+	var hash string
+	err := idx.db.QueryRow(`SELECT content_hash FROM packages WHERE repo = ? AND spec_path = ?`, repo, specPath).Scan(&hash)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return hash, err
+}
+
+// RemoveRepo deletes every indexed entry belonging to repo.
+func (idx *Index) RemoveRepo(repo string) error {
+	_, err := idx.db.Exec(`DELETE FROM packages WHERE repo = ?`, repo)
+	return err
+}
+
+// Search returns every package whose name contains query.
+func (idx *Index) Search(query string) ([]Package, error) {
+	rows, err := idx.db.Query(`
+		SELECT name, version, description, provides, replaces, depends, repo, spec_path, content_hash
+		FROM packages WHERE name LIKE ? ORDER BY name`, "%"+query+"%")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanPackages(rows)
+}
+
+// ByName returns every package exactly named name, across all repos.
+// More than one result means the name is ambiguous across repos.
+func (idx *Index) ByName(name string) ([]Package, error) {
+	rows, err := idx.db.Query(`
+		SELECT name, version, description, provides, replaces, depends, repo, spec_path, content_hash
+		FROM packages WHERE name = ? ORDER BY repo`, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanPackages(rows)
+}
+
+func scanPackages(rows *sql.Rows) ([]Package, error) {
+	var out []Package
+	for rows.Next() {
+		var p Package
+		if err := rows.Scan(&p.Name, &p.Version, &p.Description, &p.Provides, &p.Replaces, &p.Depends, &p.Repo, &p.SpecPath, &p.ContentHash); err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+// ErrAmbiguous is returned by Resolve when more than one repo provides a
+// requested package name.
+type ErrAmbiguous struct {
+	Name    string
+	Matches []Package
+}
+
+func (e *ErrAmbiguous) Error() string {
+	return fmt.Sprintf("%q is provided by %d repos; specify one explicitly", e.Name, len(e.Matches))
+}
+
+// Resolve looks up name in the index, returning the sole match or an
+// ErrAmbiguous if multiple repos provide it.
+func (idx *Index) Resolve(name string) (Package, error) {
+	matches, err := idx.ByName(name)
+	if err != nil {
+		return Package{}, err
+	}
+	switch len(matches) {
+	case 0:
+		return Package{}, fmt.Errorf("package %q not found in any configured repo", name)
+	case 1:
+		return matches[0], nil
+	default:
+		return Package{}, &ErrAmbiguous{Name: name, Matches: matches}
+	}
+}
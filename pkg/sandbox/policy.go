@@ -0,0 +1,50 @@
+// Package sandbox runs PAK shell scripts in-process with mvdan.cc/sh/v3,
+// so scripts execute without a host bash and under a policy that
+// restricts which network hosts, filesystem paths, and binaries they
+// may touch.
+package sandbox
+
+// Policy describes what a sandboxed script is allowed to do. The zero
+// value refuses every host named in curl/wget/git arguments and, with
+// WriteDir empty, places no restriction on writes.
+type Policy struct {
+	// AllowedHosts is the set of network hostnames commands invoked
+	// via ExecHandler (curl, wget, git, ...) may contact. A command
+	// whose arguments reference a host outside this list is refused.
+	//
+	// A non-nil (even empty) AllowedHosts also switches exec's default
+	// from "run anything" to "deny anything not a recognized build
+	// tool or in AllowCommands" — see execHandler.
+	AllowedHosts []string
+
+	// AllowCommands is the explicit allow-list of binaries a script
+	// may exec when AllowedHosts is set, beyond the built-in set of
+	// ordinary build tools (make, tar, cc, ...).
+	AllowCommands []string
+
+	// WriteDir confines filesystem writes: OpenHandler refuses to
+	// open any path for writing that falls outside this directory.
+	WriteDir string
+
+	// ForbidSetuid refuses to exec any binary with the setuid or
+	// setgid bit set.
+	ForbidSetuid bool
+}
+
+func (p Policy) hostAllowed(host string) bool {
+	for _, allowed := range p.AllowedHosts {
+		if allowed == host {
+			return true
+		}
+	}
+	return false
+}
+
+func (p Policy) commandAllowed(name string) bool {
+	for _, allowed := range p.AllowCommands {
+		if allowed == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package sandbox
+
+import (
+	"context"
+	"testing"
+)
+
+func TestHostOf(t *testing.T) {
+	cases := map[string]string{
+		"https://example.com/payload": "example.com",
+		"example.com/payload":         "example.com",
+		"http://localhost:8080":       "localhost",
+	}
+	for in, want := range cases {
+		if got := hostOf(in); got != want {
+			t.Errorf("hostOf(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCheckNetworkArgsDeniesDisallowedHost(t *testing.T) {
+	policy := Policy{AllowedHosts: []string{"github.com"}}
+
+	if err := checkNetworkArgs(policy, []string{"curl", "example.com/payload"}); err == nil {
+		t.Error("expected bare-host curl target outside AllowedHosts to be refused")
+	}
+	if err := checkNetworkArgs(policy, []string{"curl", "https://github.com/foo"}); err != nil {
+		t.Errorf("expected allowed host to pass, got %v", err)
+	}
+}
+
+func TestExecHandlerDeniesUnrecognizedCommandByDefault(t *testing.T) {
+	policy := Policy{AllowedHosts: []string{"github.com"}}
+
+	if buildTools["python3"] {
+		t.Fatal("test assumption broken: python3 treated as a safe build tool")
+	}
+	if policy.commandAllowed("python3") {
+		t.Fatal("test assumption broken: python3 unexpectedly allow-listed")
+	}
+	if err := execHandler(policy)(context.Background(), []string{"python3", "-c", "pass"}); err == nil {
+		t.Error("expected execHandler to refuse python3 when it is neither a build tool nor allow-listed")
+	}
+}
+
+func TestPolicyCommandAllowedHonorsAllowCommands(t *testing.T) {
+	policy := Policy{AllowedHosts: []string{"github.com"}, AllowCommands: []string{"python3"}}
+
+	if !policy.commandAllowed("python3") {
+		t.Error("expected python3 to be allowed once added to AllowCommands")
+	}
+}
@@ -0,0 +1,74 @@
+package sandbox
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"mvdan.cc/sh/v3/expand"
+	"mvdan.cc/sh/v3/interp"
+	"mvdan.cc/sh/v3/syntax"
+)
+
+// Sandbox runs shell scripts in-process under a Policy, with a fixed
+// set of variables exposed to the script's environment.
+type Sandbox struct {
+	policy Policy
+	vars   map[string]string
+	dir    string
+}
+
+// New returns a Sandbox confined by policy, rooted at dir, exposing the
+// given extra variables (e.g. distro, arch, cache paths) to scripts on
+// top of the host's own environment.
+func New(policy Policy, dir string, vars map[string]string) *Sandbox {
+	return &Sandbox{policy: policy, vars: vars, dir: dir}
+}
+
+// Run parses and executes a script in the sandbox.
+func (s *Sandbox) Run(ctx context.Context, script string) error {
+	file, err := syntax.NewParser().Parse(strings.NewReader(script), "")
+	if err != nil {
+		return err
+	}
+	return s.exec(ctx, file)
+}
+
+// RunFile parses and executes the script at path, exposing args as the
+// script's positional parameters.
+func (s *Sandbox) RunFile(ctx context.Context, path string, args []string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	file, err := syntax.NewParser().Parse(strings.NewReader(string(data)), path)
+	if err != nil {
+		return err
+	}
+	return s.exec(ctx, file, args...)
+}
+
+func (s *Sandbox) exec(ctx context.Context, file *syntax.File, args ...string) error {
+	runner, err := interp.New(
+		interp.Dir(s.dir),
+		interp.Params(args...),
+		interp.Env(s.environ()),
+		interp.StdIO(os.Stdin, os.Stdout, os.Stderr),
+		interp.ExecHandler(execHandler(s.policy)),
+		interp.OpenHandler(openHandler(s.policy)),
+	)
+	if err != nil {
+		return err
+	}
+	return runner.Run(ctx, file)
+}
+
+// environ layers the sandbox's extra variables on top of the host
+// process environment.
+func (s *Sandbox) environ() expand.Environ {
+	pairs := os.Environ()
+	for k, v := range s.vars {
+		pairs = append(pairs, k+"="+v)
+	}
+	return expand.ListEnviron(pairs...)
+}
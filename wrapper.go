@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"os"
+	"runtime"
+
+	"github.com/cyber-boost/pak/pkg/distro"
+	"github.com/cyber-boost/pak/pkg/paths"
+	"github.com/cyber-boost/pak/pkg/sandbox"
+)
+
+// runWrapperScript executes the legacy pak-sh wrapper script in-process
+// via pkg/sandbox instead of shelling out to it. This works even on
+// hosts without bash (Windows, minimal containers) and confines the
+// script to a policy instead of trusting it unconditionally.
+func runWrapperScript(wrapperPath string, args ...string) error {
+	sb, err := newWrapperSandbox()
+	if err != nil {
+		return err
+	}
+	return sb.RunFile(context.Background(), wrapperPath, args)
+}
+
+// newWrapperSandbox builds the Sandbox every wrapper-script invocation
+// shares: writes confined to the user's XDG data dir, and distro/arch/
+// cache variables available to the script without it having to
+// re-detect them.
+func newWrapperSandbox() (*sandbox.Sandbox, error) {
+	dataDir, err := paths.DataHome()
+	if err != nil {
+		return nil, err
+	}
+	cacheDir, err := paths.CacheHome()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := paths.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	vars := map[string]string{
+		"PAK_CACHE_HOME": cacheDir,
+		"PAK_DATA_HOME":  dataDir,
+		"PAK_ARCH":       runtime.GOARCH,
+	}
+	if info, err := distro.Detect(); err == nil && info.Family != "" {
+		vars["PAK_DISTRO"] = info.ID
+		vars["PAK_DISTRO_FAMILY"] = info.Family
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	return sandbox.New(sandbox.Policy{
+		WriteDir:      dataDir,
+		AllowedHosts:  cfg.SandboxAllowedHosts,
+		AllowCommands: cfg.SandboxAllowCommands,
+	}, wd, vars), nil
+}